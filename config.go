@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is the overall health classification surfaced to monitoring
+// systems via exit code and to the HTML report via a top banner.
+type Severity string
+
+const (
+	SeverityOK   Severity = "OK"
+	SeverityWarn Severity = "WARN"
+	SeverityCrit Severity = "CRIT"
+)
+
+// MountThreshold overrides the global disk thresholds for one mountpoint.
+type MountThreshold struct {
+	WarnPct float64 `yaml:"warn_pct"`
+	CritPct float64 `yaml:"crit_pct"`
+}
+
+// Thresholds is the shape of the -config YAML file. Any field the file leaves
+// unset keeps the corresponding default from defaultThresholds; a threshold
+// explicitly set to 0 disables its rule.
+type Thresholds struct {
+	Disk struct {
+		WarnPct float64                   `yaml:"warn_pct"`
+		CritPct float64                   `yaml:"crit_pct"`
+		Mounts  map[string]MountThreshold `yaml:"mounts"`
+	} `yaml:"disk"`
+
+	CPU struct {
+		Load1Warn float64 `yaml:"load1_warn"`
+	} `yaml:"cpu"`
+
+	Mem struct {
+		SwapUsedCritPct float64 `yaml:"swap_used_crit"`
+	} `yaml:"mem"`
+
+	Errpt struct {
+		Perm24hCrit int               `yaml:"perm_24h_crit"`
+		Labels      map[string]string `yaml:"labels"` // LABEL -> "suppress" | "escalate"
+	} `yaml:"errpt"`
+}
+
+// resolveDiskWarnThreshold returns the warn-percent threshold that applies to
+// mount, falling back to the global th.Disk.WarnPct when no per-mount
+// override is set. Shared by getDiskInfo (to flag rows) and evaluateHealth
+// (to explain the verdict), so both agree on what "over threshold" means.
+func resolveDiskWarnThreshold(mount string, th Thresholds) float64 {
+	warn := th.Disk.WarnPct
+	if o, ok := th.Disk.Mounts[mount]; ok && o.WarnPct > 0 {
+		warn = o.WarnPct
+	}
+	return warn
+}
+
+// defaultThresholds returns the thresholds used when -config is unset.
+func defaultThresholds() Thresholds {
+	var th Thresholds
+	th.Disk.WarnPct = 80
+	th.Disk.CritPct = 95
+	th.CPU.Load1Warn = 4
+	th.Mem.SwapUsedCritPct = 80
+	th.Errpt.Perm24hCrit = 5
+	return th
+}
+
+// loadThresholds returns the default thresholds when path is empty,
+// otherwise parses path as YAML on top of the defaults so a config only
+// needs to set the fields it wants to override.
+func loadThresholds(path string) (Thresholds, error) {
+	th := defaultThresholds()
+	if path == "" {
+		return th, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return th, err
+	}
+	if err := yaml.Unmarshal(data, &th); err != nil {
+		return th, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return th, nil
+}
+
+// HealthVerdict is the machine-readable outcome of evaluating a Report
+// against a set of Thresholds, suitable for Nagios/check_mk/Zabbix style
+// exit-code contracts and for the HTML banner.
+type HealthVerdict struct {
+	Severity Severity
+	Reasons  []string
+}
+
+// exitCodeFor maps a verdict to the 0/1/2 contract monitoring systems expect.
+func exitCodeFor(v HealthVerdict) int {
+	switch v.Severity {
+	case SeverityCrit:
+		return 2
+	case SeverityWarn:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func severityRank(s Severity) int {
+	switch s {
+	case SeverityCrit:
+		return 2
+	case SeverityWarn:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// evaluateHealth runs every threshold rule against rep and returns the
+// highest severity triggered along with the reason for each rule that fired.
+func evaluateHealth(rep Report, th Thresholds) HealthVerdict {
+	v := HealthVerdict{Severity: SeverityOK}
+	bump := func(sev Severity, reason string) {
+		if severityRank(sev) > severityRank(v.Severity) {
+			v.Severity = sev
+		}
+		v.Reasons = append(v.Reasons, reason)
+	}
+
+	for _, row := range rep.Disks.Rows {
+		warn, crit := resolveDiskWarnThreshold(row.Mountpoint, th), th.Disk.CritPct
+		if o, ok := th.Disk.Mounts[row.Mountpoint]; ok && o.CritPct > 0 {
+			crit = o.CritPct
+		}
+		switch {
+		case crit > 0 && row.UsePct >= crit:
+			bump(SeverityCrit, fmt.Sprintf("disk %s at %.2f%% (>= crit %.2f%%)", row.Mountpoint, row.UsePct, crit))
+		case warn > 0 && row.UsePct >= warn:
+			bump(SeverityWarn, fmt.Sprintf("disk %s at %.2f%% (>= warn %.2f%%)", row.Mountpoint, row.UsePct, warn))
+		}
+	}
+
+	if th.CPU.Load1Warn > 0 && rep.CPU.Load1 >= th.CPU.Load1Warn {
+		bump(SeverityWarn, fmt.Sprintf("load1 %.2f (>= warn %.2f)", rep.CPU.Load1, th.CPU.Load1Warn))
+	}
+
+	if th.Mem.SwapUsedCritPct > 0 && rep.Memory.SwapUsedPercent >= th.Mem.SwapUsedCritPct {
+		bump(SeverityCrit, fmt.Sprintf("swap used %.2f%% (>= crit %.2f%%)", rep.Memory.SwapUsedPercent, th.Mem.SwapUsedCritPct))
+	}
+
+	permCount := rep.Errors.Last24hSummary.Permanent
+	escalated := false
+	for _, e := range rep.Errors.Last24hEntries {
+		switch th.Errpt.Labels[e.Label] {
+		case "suppress":
+			if e.Type == "PERM" {
+				permCount--
+			}
+		case "escalate":
+			escalated = true
+		}
+	}
+	if escalated {
+		bump(SeverityCrit, "errpt label escalation rule matched")
+	}
+	if th.Errpt.Perm24hCrit > 0 && permCount >= th.Errpt.Perm24hCrit {
+		bump(SeverityCrit, fmt.Sprintf("%d PERM errpt entries in last 24h (>= crit %d)", permCount, th.Errpt.Perm24hCrit))
+	}
+
+	if len(v.Reasons) == 0 {
+		v.Reasons = append(v.Reasons, "all checks within thresholds")
+	}
+	return v
+}
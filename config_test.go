@@ -0,0 +1,121 @@
+package main
+
+import "testing"
+
+func TestEvaluateHealth(t *testing.T) {
+	th := defaultThresholds()
+
+	cases := []struct {
+		name string
+		rep  Report
+		want Severity
+	}{
+		{
+			name: "all healthy",
+			rep:  Report{},
+			want: SeverityOK,
+		},
+		{
+			name: "disk over global warn",
+			rep:  Report{Disks: DiskInfo{Rows: []DiskUsageRow{{Mountpoint: "/", UsePct: 85}}}},
+			want: SeverityWarn,
+		},
+		{
+			name: "disk over global crit",
+			rep:  Report{Disks: DiskInfo{Rows: []DiskUsageRow{{Mountpoint: "/", UsePct: 96}}}},
+			want: SeverityCrit,
+		},
+		{
+			name: "load1 over warn",
+			rep:  Report{CPU: CPUInfo{Load1: 10}},
+			want: SeverityWarn,
+		},
+		{
+			name: "swap used over crit",
+			rep:  Report{Memory: MemInfo{SwapUsedPercent: 90}},
+			want: SeverityCrit,
+		},
+		{
+			name: "perm errpt count over crit",
+			rep:  Report{Errors: ErrorLog{Last24hSummary: ErrSummary{Permanent: 5}}},
+			want: SeverityCrit,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := evaluateHealth(c.rep, th)
+			if got.Severity != c.want {
+				t.Errorf("evaluateHealth(%+v) severity = %s, want %s (reasons: %v)", c.rep, got.Severity, c.want, got.Reasons)
+			}
+		})
+	}
+}
+
+func TestEvaluateHealthDiskMountOverride(t *testing.T) {
+	th := defaultThresholds()
+	th.Disk.Mounts = map[string]MountThreshold{"/tmp": {WarnPct: 90, CritPct: 98}}
+
+	below := Report{Disks: DiskInfo{Rows: []DiskUsageRow{{Mountpoint: "/tmp", UsePct: 85}}}}
+	if got := evaluateHealth(below, th); got.Severity != SeverityOK {
+		t.Errorf("below /tmp override: severity = %s, want OK (reasons: %v)", got.Severity, got.Reasons)
+	}
+
+	above := Report{Disks: DiskInfo{Rows: []DiskUsageRow{{Mountpoint: "/tmp", UsePct: 92}}}}
+	if got := evaluateHealth(above, th); got.Severity != SeverityWarn {
+		t.Errorf("above /tmp override: severity = %s, want WARN", got.Severity)
+	}
+}
+
+func TestEvaluateHealthErrptLabelRules(t *testing.T) {
+	th := defaultThresholds()
+	th.Errpt.Perm24hCrit = 1
+	th.Errpt.Labels = map[string]string{"DISK_ERR3": "escalate", "NOISY_LABEL": "suppress"}
+
+	suppressed := Report{
+		Errors: ErrorLog{
+			Last24hSummary: ErrSummary{Permanent: 1},
+			Last24hEntries: []ErrptEntry{{Label: "NOISY_LABEL", Type: "PERM"}},
+		},
+	}
+	if got := evaluateHealth(suppressed, th); got.Severity != SeverityOK {
+		t.Errorf("suppressed label: severity = %s, want OK (reasons: %v)", got.Severity, got.Reasons)
+	}
+
+	escalated := Report{
+		Errors: ErrorLog{
+			Last24hEntries: []ErrptEntry{{Label: "DISK_ERR3", Type: "TEMP"}},
+		},
+	}
+	if got := evaluateHealth(escalated, th); got.Severity != SeverityCrit {
+		t.Errorf("escalated label: severity = %s, want CRIT", got.Severity)
+	}
+}
+
+func TestResolveDiskWarnThreshold(t *testing.T) {
+	th := defaultThresholds()
+	th.Disk.Mounts = map[string]MountThreshold{"/tmp": {WarnPct: 90}}
+
+	if got := resolveDiskWarnThreshold("/", th); got != th.Disk.WarnPct {
+		t.Errorf("resolveDiskWarnThreshold(/) = %.2f, want global %.2f", got, th.Disk.WarnPct)
+	}
+	if got := resolveDiskWarnThreshold("/tmp", th); got != 90 {
+		t.Errorf("resolveDiskWarnThreshold(/tmp) = %.2f, want override 90", got)
+	}
+}
+
+func TestExitCodeFor(t *testing.T) {
+	cases := []struct {
+		sev  Severity
+		want int
+	}{
+		{SeverityOK, 0},
+		{SeverityWarn, 1},
+		{SeverityCrit, 2},
+	}
+	for _, c := range cases {
+		if got := exitCodeFor(HealthVerdict{Severity: c.sev}); got != c.want {
+			t.Errorf("exitCodeFor(%s) = %d, want %d", c.sev, got, c.want)
+		}
+	}
+}
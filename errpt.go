@@ -0,0 +1,263 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrptEntry is a single parsed `errpt -a` entry, replacing the raw
+// concatenated text block the HTML report used to render with a real
+// sortable table.
+type ErrptEntry struct {
+	Label         string
+	Identifier    string
+	Class         string
+	Type          string
+	ResourceName  string
+	ResourceClass string
+	ResourceType  string
+	Location      string
+	Timestamp     time.Time
+
+	Description        string
+	ProbableCauses     []string
+	UserCauses         []string
+	RecommendedActions []string
+	DetailData         string
+}
+
+// parseErrptEntry turns one raw entry (as produced by splitErrptEntries)
+// into a structured ErrptEntry. Unrecognized lines are ignored, matching the
+// tool's existing best-effort approach to AIX's fixed-column output.
+func parseErrptEntry(raw string) ErrptEntry {
+	var e ErrptEntry
+	section := ""
+
+	for _, ln := range strings.Split(raw, "\n") {
+		trim := strings.TrimSpace(ln)
+
+		switch {
+		case trim == "":
+			continue
+		case strings.HasPrefix(trim, "LABEL:"):
+			e.Label = strings.TrimSpace(strings.TrimPrefix(trim, "LABEL:"))
+			section = ""
+			continue
+		case strings.HasPrefix(trim, "IDENTIFIER:"):
+			e.Identifier = strings.TrimSpace(strings.TrimPrefix(trim, "IDENTIFIER:"))
+			section = ""
+			continue
+		case strings.HasPrefix(trim, "Class:"):
+			e.Class = strings.TrimSpace(strings.TrimPrefix(trim, "Class:"))
+			section = ""
+			continue
+		case strings.HasPrefix(trim, "Type:"):
+			e.Type = strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(trim, "Type:")))
+			section = ""
+			continue
+		case strings.HasPrefix(trim, "Resource Name:"):
+			e.ResourceName = strings.TrimSpace(strings.TrimPrefix(trim, "Resource Name:"))
+			section = ""
+			continue
+		case strings.HasPrefix(trim, "Resource Class:"):
+			e.ResourceClass = strings.TrimSpace(strings.TrimPrefix(trim, "Resource Class:"))
+			section = ""
+			continue
+		case strings.HasPrefix(trim, "Resource Type:"):
+			e.ResourceType = strings.TrimSpace(strings.TrimPrefix(trim, "Resource Type:"))
+			section = ""
+			continue
+		case strings.HasPrefix(trim, "Location:"):
+			e.Location = strings.TrimSpace(strings.TrimPrefix(trim, "Location:"))
+			section = ""
+			continue
+		case trim == "Description":
+			section = "description"
+			continue
+		case trim == "Probable Causes":
+			section = "probable"
+			continue
+		case trim == "Failure Causes" || trim == "User Causes":
+			section = "user"
+			continue
+		case trim == "Recommended Actions":
+			section = "actions"
+			continue
+		case trim == "Detail Data":
+			section = "detail"
+			continue
+		}
+
+		switch section {
+		case "description":
+			if e.Description != "" {
+				e.Description += " "
+			}
+			e.Description += trim
+		case "probable":
+			e.ProbableCauses = append(e.ProbableCauses, trim)
+		case "user":
+			e.UserCauses = append(e.UserCauses, trim)
+		case "actions":
+			e.RecommendedActions = append(e.RecommendedActions, trim)
+		case "detail":
+			if e.DetailData != "" {
+				e.DetailData += "\n"
+			}
+			e.DetailData += trim
+		}
+	}
+
+	if ts, ok := extractErrptTime(raw); ok {
+		e.Timestamp = ts
+	}
+	return e
+}
+
+// summarizeTypedEntries tallies entries by their Type field.
+func summarizeTypedEntries(entries []ErrptEntry) ErrSummary {
+	var s ErrSummary
+	for _, e := range entries {
+		s.Total++
+		switch e.Type {
+		case "PERM":
+			s.Permanent++
+		case "TEMP":
+			s.Temporary++
+		case "INFO":
+			s.Informational++
+		default:
+			s.Unknown++
+		}
+	}
+	return s
+}
+
+// ErrptGroup collapses repeated entries that share a (Label, ResourceName)
+// pair into a single row with a count and first/last-seen timestamps.
+type ErrptGroup struct {
+	Label        string
+	ResourceName string
+	Type         string
+	Description  string
+	Count        int
+	FirstSeen    time.Time
+	LastSeen     time.Time
+}
+
+// groupErrptEntries collapses entries by (Label, ResourceName), sorted by
+// count descending so the noisiest repeats surface first.
+func groupErrptEntries(entries []ErrptEntry) []ErrptGroup {
+	type key struct{ label, resource string }
+	groups := make(map[key]*ErrptGroup)
+	var order []key
+
+	for _, e := range entries {
+		k := key{e.Label, e.ResourceName}
+		g, ok := groups[k]
+		if !ok {
+			g = &ErrptGroup{
+				Label:        e.Label,
+				ResourceName: e.ResourceName,
+				Type:         e.Type,
+				Description:  e.Description,
+				FirstSeen:    e.Timestamp,
+				LastSeen:     e.Timestamp,
+			}
+			groups[k] = g
+			order = append(order, k)
+		}
+		g.Count++
+		if e.Timestamp.Before(g.FirstSeen) {
+			g.FirstSeen = e.Timestamp
+		}
+		if e.Timestamp.After(g.LastSeen) {
+			g.LastSeen = e.Timestamp
+		}
+	}
+
+	out := make([]ErrptGroup, 0, len(order))
+	for _, k := range order {
+		out = append(out, *groups[k])
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].LastSeen.After(out[j].LastSeen)
+	})
+	return out
+}
+
+// ResourceCount is one row of the "noisiest resources" summary.
+type ResourceCount struct {
+	ResourceName string
+	Count        int
+}
+
+// noisiestResources returns the topN resource names by entry count.
+func noisiestResources(entries []ErrptEntry, topN int) []ResourceCount {
+	counts := make(map[string]int)
+	var order []string
+	for _, e := range entries {
+		if e.ResourceName == "" {
+			continue
+		}
+		if _, ok := counts[e.ResourceName]; !ok {
+			order = append(order, e.ResourceName)
+		}
+		counts[e.ResourceName]++
+	}
+
+	out := make([]ResourceCount, 0, len(order))
+	for _, name := range order {
+		out = append(out, ResourceCount{ResourceName: name, Count: counts[name]})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Count > out[j].Count })
+	if len(out) > topN {
+		out = out[:topN]
+	}
+	return out
+}
+
+// LabelTrend is the hourly occurrence count for one LABEL over the trailing
+// 24 hours, oldest bucket first.
+type LabelTrend struct {
+	Label   string
+	Buckets []int
+}
+
+// buildLabelTrends buckets entries by LABEL into 24 trailing hourly buckets
+// ending at now.
+func buildLabelTrends(entries []ErrptEntry, now time.Time) []LabelTrend {
+	const buckets = 24
+	start := now.Add(-buckets * time.Hour)
+
+	counts := make(map[string][]int)
+	var order []string
+	for _, e := range entries {
+		if e.Label == "" || e.Timestamp.Before(start) {
+			continue
+		}
+		idx := int(e.Timestamp.Sub(start) / time.Hour)
+		if idx < 0 {
+			idx = 0
+		}
+		if idx >= buckets {
+			idx = buckets - 1
+		}
+		if _, ok := counts[e.Label]; !ok {
+			counts[e.Label] = make([]int, buckets)
+			order = append(order, e.Label)
+		}
+		counts[e.Label][idx]++
+	}
+
+	sort.Strings(order)
+	out := make([]LabelTrend, 0, len(order))
+	for _, label := range order {
+		out = append(out, LabelTrend{Label: label, Buckets: counts[label]})
+	}
+	return out
+}
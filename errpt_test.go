@@ -0,0 +1,171 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+const sampleErrptEntry = `LABEL:          DISK_ERR3
+IDENTIFIER:     EA88F05D
+
+Date/Time:       Fri Oct  3 12:12:21 AST 2025
+Sequence Number: 123456
+Machine Id:      00F9A1234C00
+Node Id:         aix1
+Class:           H
+Type:            PERM
+Resource Name:   hdisk2
+Resource Class:  disk
+Resource Type:   scsd
+Location:        U78CB.001.WZS0043-P1-C2-T1
+
+Description
+DISK OPERATION ERROR
+
+Probable Causes
+DISK DRIVE
+ADAPTER
+
+Failure Causes
+DISK DRIVE
+
+Recommended Actions
+PERFORM PROBLEM DETERMINATION PROCEDURES
+
+Detail Data
+SENSE DATA
+0A00 2800`
+
+func TestParseErrptEntry(t *testing.T) {
+	e := parseErrptEntry(sampleErrptEntry)
+
+	cases := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Label", e.Label, "DISK_ERR3"},
+		{"Identifier", e.Identifier, "EA88F05D"},
+		{"Class", e.Class, "H"},
+		{"Type", e.Type, "PERM"},
+		{"ResourceName", e.ResourceName, "hdisk2"},
+		{"ResourceClass", e.ResourceClass, "disk"},
+		{"ResourceType", e.ResourceType, "scsd"},
+		{"Location", e.Location, "U78CB.001.WZS0043-P1-C2-T1"},
+		{"Description", e.Description, "DISK OPERATION ERROR"},
+		{"DetailData", e.DetailData, "SENSE DATA\n0A00 2800"},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s = %q, want %q", c.name, c.got, c.want)
+		}
+	}
+
+	if want := []string{"DISK DRIVE", "ADAPTER"}; !equalStrings(e.ProbableCauses, want) {
+		t.Errorf("ProbableCauses = %v, want %v", e.ProbableCauses, want)
+	}
+	if want := []string{"DISK DRIVE"}; !equalStrings(e.UserCauses, want) {
+		t.Errorf("UserCauses = %v, want %v", e.UserCauses, want)
+	}
+	if want := []string{"PERFORM PROBLEM DETERMINATION PROCEDURES"}; !equalStrings(e.RecommendedActions, want) {
+		t.Errorf("RecommendedActions = %v, want %v", e.RecommendedActions, want)
+	}
+
+	if e.Timestamp.IsZero() {
+		t.Fatal("Timestamp not parsed")
+	}
+	if y, m, d := e.Timestamp.Date(); y != 2025 || m != time.October || d != 3 {
+		t.Errorf("Timestamp date = %d-%s-%d, want 2025-October-3", y, m, d)
+	}
+	if h, mi, s := e.Timestamp.Clock(); h != 12 || mi != 12 || s != 21 {
+		t.Errorf("Timestamp clock = %02d:%02d:%02d, want 12:12:21", h, mi, s)
+	}
+}
+
+func equalStrings(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSummarizeTypedEntries(t *testing.T) {
+	entries := []ErrptEntry{
+		{Type: "PERM"}, {Type: "PERM"}, {Type: "TEMP"}, {Type: "INFO"}, {Type: "UNKN"},
+	}
+	got := summarizeTypedEntries(entries)
+	want := ErrSummary{Total: 5, Permanent: 2, Temporary: 1, Informational: 1, Unknown: 1}
+	if got != want {
+		t.Errorf("summarizeTypedEntries() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGroupErrptEntries(t *testing.T) {
+	now := time.Date(2026, 7, 26, 12, 0, 0, 0, time.UTC)
+	entries := []ErrptEntry{
+		{Label: "DISK_ERR3", ResourceName: "hdisk2", Timestamp: now.Add(-2 * time.Hour)},
+		{Label: "DISK_ERR3", ResourceName: "hdisk2", Timestamp: now.Add(-1 * time.Hour)},
+		{Label: "NET_ERR", ResourceName: "en0", Timestamp: now},
+	}
+
+	groups := groupErrptEntries(entries)
+	if len(groups) != 2 {
+		t.Fatalf("len(groups) = %d, want 2", len(groups))
+	}
+	if groups[0].Label != "DISK_ERR3" || groups[0].Count != 2 {
+		t.Errorf("groups[0] = %+v, want the repeated DISK_ERR3/hdisk2 group with count 2", groups[0])
+	}
+	if !groups[0].LastSeen.Equal(now.Add(-1 * time.Hour)) {
+		t.Errorf("groups[0].LastSeen = %v, want %v", groups[0].LastSeen, now.Add(-1*time.Hour))
+	}
+}
+
+func TestNoisiestResources(t *testing.T) {
+	entries := []ErrptEntry{
+		{ResourceName: "hdisk2"}, {ResourceName: "hdisk2"}, {ResourceName: "hdisk2"},
+		{ResourceName: "en0"}, {ResourceName: "en0"},
+		{ResourceName: "hdisk5"},
+		{ResourceName: ""},
+	}
+	got := noisiestResources(entries, 2)
+	if len(got) != 2 {
+		t.Fatalf("len(got) = %d, want 2", len(got))
+	}
+	if got[0].ResourceName != "hdisk2" || got[0].Count != 3 {
+		t.Errorf("got[0] = %+v, want hdisk2 with count 3", got[0])
+	}
+	if got[1].ResourceName != "en0" || got[1].Count != 2 {
+		t.Errorf("got[1] = %+v, want en0 with count 2", got[1])
+	}
+}
+
+func TestBuildLabelTrends(t *testing.T) {
+	now := time.Date(2026, 7, 26, 12, 0, 0, 0, time.UTC)
+	entries := []ErrptEntry{
+		{Label: "DISK_ERR3", Timestamp: now.Add(-24 * time.Hour)},   // oldest bucket (index 0)
+		{Label: "DISK_ERR3", Timestamp: now},                        // newest bucket (clamped to index 23)
+		{Label: "STALE_LABEL", Timestamp: now.Add(-25 * time.Hour)}, // outside the 24h window
+	}
+
+	trends := buildLabelTrends(entries, now)
+	if len(trends) != 1 {
+		t.Fatalf("len(trends) = %d, want 1 (STALE_LABEL should be dropped)", len(trends))
+	}
+	if trends[0].Label != "DISK_ERR3" {
+		t.Fatalf("trends[0].Label = %q, want DISK_ERR3", trends[0].Label)
+	}
+	if len(trends[0].Buckets) != 24 {
+		t.Fatalf("len(Buckets) = %d, want 24", len(trends[0].Buckets))
+	}
+	if trends[0].Buckets[0] != 1 {
+		t.Errorf("Buckets[0] = %d, want 1 (oldest bucket)", trends[0].Buckets[0])
+	}
+	if trends[0].Buckets[23] != 1 {
+		t.Errorf("Buckets[23] = %d, want 1 (newest bucket)", trends[0].Buckets[23])
+	}
+}
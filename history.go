@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// historySampleWindow caps how many prior samples are loaded to compute
+// rates and sparklines; historyRetentionDays caps how long raw samples are
+// kept on disk regardless of how often the tool is run.
+const (
+	historySampleWindow  = 20
+	historyRetentionDays = 14
+)
+
+// DiskIORate is a derived read/write throughput for one device between the
+// previous sample and the current one.
+type DiskIORate struct {
+	Name             string
+	ReadBytesPerSec  float64
+	WriteBytesPerSec float64
+}
+
+// Trend holds everything the HTML template needs to render rates,
+// sparklines, and a diff against the previous run. It is always attached to
+// a Report but only populated when -history is set.
+type Trend struct {
+	HasHistory   bool
+	SampleCount  int
+	DiskIORates  []DiskIORate
+	ErrptPerHour float64
+
+	NewErrptLabels []string
+	NewlyOver80    []string
+
+	MemUsedPctSeries []float64
+	DiskUsePctSeries map[string][]float64
+}
+
+// recordAndTrend appends rep to the rolling history file under dir, prunes
+// samples older than historyRetentionDays, and populates rep.Trend from the
+// samples that preceded it.
+func recordAndTrend(rep *Report, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, "history.jsonl")
+
+	prior, err := loadHistory(path, historySampleWindow)
+	if err != nil {
+		return err
+	}
+	if err := appendHistory(*rep, path); err != nil {
+		return err
+	}
+	if err := pruneHistory(path, historyRetentionDays); err != nil {
+		return err
+	}
+
+	rep.Trend = buildTrend(prior, *rep)
+	return nil
+}
+
+// appendHistory writes rep as a single JSON line to path.
+func appendHistory(rep Report, path string) error {
+	data, err := json.Marshal(rep)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(data)
+	return err
+}
+
+// loadHistory reads up to the last limit samples from path, oldest first. A
+// missing file is not an error: it just means there is no history yet.
+func loadHistory(path string, limit int) ([]Report, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var reports []Report
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for sc.Scan() {
+		var r Report
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			continue
+		}
+		reports = append(reports, r)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(reports) > limit {
+		reports = reports[len(reports)-limit:]
+	}
+	return reports, nil
+}
+
+// pruneHistory rewrites path keeping only samples newer than retentionDays.
+func pruneHistory(path string, retentionDays int) error {
+	reports, err := loadHistory(path, 1<<30)
+	if err != nil {
+		return err
+	}
+	cut := time.Now().AddDate(0, 0, -retentionDays)
+
+	var buf strings.Builder
+	for _, r := range reports {
+		if r.GeneratedAt.Before(cut) {
+			continue
+		}
+		data, err := json.Marshal(r)
+		if err != nil {
+			continue
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return os.WriteFile(path, []byte(buf.String()), 0644)
+}
+
+// buildTrend derives rates, a diff against the most recent prior sample, and
+// sparkline series from the full prior window plus the current sample.
+func buildTrend(prior []Report, current Report) Trend {
+	t := Trend{SampleCount: len(prior)}
+	if len(prior) == 0 {
+		return t
+	}
+	t.HasHistory = true
+	last := prior[len(prior)-1]
+
+	if dt := current.GeneratedAt.Sub(last.GeneratedAt).Seconds(); dt > 0 {
+		ioByName := make(map[string]DiskIOStat, len(last.Disks.IOStats))
+		for _, io := range last.Disks.IOStats {
+			ioByName[io.Name] = io
+		}
+		for _, io := range current.Disks.IOStats {
+			prev, ok := ioByName[io.Name]
+			if !ok || io.ReadBytesRaw < prev.ReadBytesRaw || io.WriteBytesRaw < prev.WriteBytesRaw {
+				continue
+			}
+			t.DiskIORates = append(t.DiskIORates, DiskIORate{
+				Name:             io.Name,
+				ReadBytesPerSec:  float64(io.ReadBytesRaw-prev.ReadBytesRaw) / dt,
+				WriteBytesPerSec: float64(io.WriteBytesRaw-prev.WriteBytesRaw) / dt,
+			})
+		}
+		if current.Errors.AllTimeSummary.Total >= last.Errors.AllTimeSummary.Total {
+			t.ErrptPerHour = float64(current.Errors.AllTimeSummary.Total-last.Errors.AllTimeSummary.Total) / (dt / 3600.0)
+		}
+	}
+
+	seenLabels := make(map[string]bool)
+	for _, e := range last.Errors.Last24hEntries {
+		seenLabels[e.Label] = true
+	}
+	for _, e := range current.Errors.Last24hEntries {
+		if e.Label != "" && !seenLabels[e.Label] {
+			t.NewErrptLabels = append(t.NewErrptLabels, e.Label)
+		}
+	}
+
+	wasWarn := make(map[string]bool, len(last.Disks.Rows))
+	for _, r := range last.Disks.Rows {
+		wasWarn[r.Mountpoint] = r.Warn
+	}
+	for _, r := range current.Disks.Rows {
+		if r.Warn && !wasWarn[r.Mountpoint] {
+			t.NewlyOver80 = append(t.NewlyOver80, r.Mountpoint)
+		}
+	}
+
+	for _, p := range prior {
+		t.MemUsedPctSeries = append(t.MemUsedPctSeries, p.Memory.UsedPercent)
+	}
+	t.MemUsedPctSeries = append(t.MemUsedPctSeries, current.Memory.UsedPercent)
+
+	t.DiskUsePctSeries = make(map[string][]float64)
+	for _, p := range prior {
+		for _, r := range p.Disks.Rows {
+			t.DiskUsePctSeries[r.Mountpoint] = append(t.DiskUsePctSeries[r.Mountpoint], r.UsePct)
+		}
+	}
+	for _, r := range current.Disks.Rows {
+		t.DiskUsePctSeries[r.Mountpoint] = append(t.DiskUsePctSeries[r.Mountpoint], r.UsePct)
+	}
+
+	return t
+}
+
+// renderSparkline renders values as a minimal inline SVG sparkline,
+// normalized to their own min/max. Fewer than two points renders nothing.
+func renderSparkline(values []float64) template.HTML {
+	if len(values) < 2 {
+		return ""
+	}
+	const w, h = 120.0, 24.0
+	min, max := values[0], values[0]
+	for _, v := range values {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	rng := max - min
+	if rng == 0 {
+		rng = 1
+	}
+	step := w / float64(len(values)-1)
+
+	var pts strings.Builder
+	for i, v := range values {
+		if i > 0 {
+			pts.WriteByte(' ')
+		}
+		fmt.Fprintf(&pts, "%.1f,%.1f", float64(i)*step, h-((v-min)/rng)*h)
+	}
+	return template.HTML(fmt.Sprintf(
+		`<svg width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f"><polyline points="%s" fill="none" stroke="#12b886" stroke-width="1.5"/></svg>`,
+		w, h, w, h, pts.String()))
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// LPARInfo captures AIX Power9 LPAR/entitlement metrics that gopsutil has no
+// concept of: entitled capacity, physical CPUs consumed, SMT thread state,
+// and paging-space I/O. Shared-pool utilization is not collected. These come
+// from shelling out to lparstat/mpstat/vmstat rather than a Go API, since AIX
+// exposes none.
+type LPARInfo struct {
+	PartitionName    string
+	Type             string // e.g. "Shared-SMT-4"
+	Mode             string // Capped / Uncapped
+	OnlineVCPUs      int
+	EntitledCapacity float64
+	PhysConsumed     float64
+	PctEntc          float64
+	SMTThreads       int
+	PerThreadPct     []float64
+
+	PagingSpacePageIn  uint64
+	PagingSpacePageOut uint64
+
+	Note string // non-fatal errors from any of the underlying commands
+}
+
+// getLPARInfo shells out to lparstat/mpstat/vmstat and merges their output
+// into a single LPARInfo. Each command is best-effort: a failure only
+// appends to Note rather than aborting the whole subsystem, matching how
+// getErrors handles a missing errpt binary.
+func getLPARInfo() LPARInfo {
+	var li LPARInfo
+	var notes []string
+
+	if out, err := exec.Command("lparstat", "-i").Output(); err == nil {
+		parseLparstatI(string(out), &li)
+	} else {
+		notes = append(notes, "lparstat -i: "+err.Error())
+	}
+
+	if out, err := exec.Command("lparstat", "1", "1").Output(); err == nil {
+		parseLparstat1(string(out), &li)
+	} else {
+		notes = append(notes, "lparstat 1 1: "+err.Error())
+	}
+
+	if out, err := exec.Command("mpstat", "-s").Output(); err == nil {
+		li.PerThreadPct = parseMpstatS(string(out))
+		li.SMTThreads = len(li.PerThreadPct)
+	} else {
+		notes = append(notes, "mpstat -s: "+err.Error())
+	}
+
+	if out, err := exec.Command("vmstat", "-Iwt", "1", "1").Output(); err == nil {
+		parseVmstatI(string(out), &li)
+	} else {
+		notes = append(notes, "vmstat -Iwt 1 1: "+err.Error())
+	}
+
+	li.Note = strings.Join(notes, "; ")
+	return li
+}
+
+// parseLparstatI parses the "Key : Value" fixed-format output of `lparstat -i`.
+func parseLparstatI(out string, li *LPARInfo) {
+	for _, ln := range strings.Split(out, "\n") {
+		parts := strings.SplitN(ln, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key := strings.TrimSpace(parts[0])
+		val := strings.TrimSpace(parts[1])
+		switch key {
+		case "Partition Name":
+			li.PartitionName = val
+		case "Type":
+			li.Type = val
+		case "Mode":
+			li.Mode = val
+		case "Entitled Capacity":
+			li.EntitledCapacity, _ = strconv.ParseFloat(val, 64)
+		case "Online Virtual CPUs":
+			li.OnlineVCPUs, _ = strconv.Atoi(val)
+		}
+	}
+}
+
+// parseLparstat1 pulls physc (physical CPUs consumed) and %entc (percentage
+// of entitlement consumed) from the header + single data row that
+// `lparstat 1 1` prints after its "System configuration:" banner.
+func parseLparstat1(out string, li *LPARInfo) {
+	cols, row := findColumnRow(out, "physc", "%entc")
+	if cols == nil {
+		return
+	}
+	if v, ok := columnValue(cols, row, "physc"); ok {
+		li.PhysConsumed, _ = strconv.ParseFloat(v, 64)
+	}
+	if v, ok := columnValue(cols, row, "%entc"); ok {
+		li.PctEntc, _ = strconv.ParseFloat(v, 64)
+	}
+}
+
+// parseVmstatI pulls the pi/po (page-in/page-out) columns from the header +
+// single data row that `vmstat -Iwt 1 1` prints.
+func parseVmstatI(out string, li *LPARInfo) {
+	cols, row := findColumnRow(out, "pi", "po")
+	if cols == nil {
+		return
+	}
+	if v, ok := columnValue(cols, row, "pi"); ok {
+		li.PagingSpacePageIn, _ = strconv.ParseUint(v, 10, 64)
+	}
+	if v, ok := columnValue(cols, row, "po"); ok {
+		li.PagingSpacePageOut, _ = strconv.ParseUint(v, 10, 64)
+	}
+}
+
+// parseMpstatS extracts the per-SMT-thread utilization percentages from the
+// "cpuN ... %" table printed by `mpstat -s`.
+func parseMpstatS(out string) []float64 {
+	var pct []float64
+	lines := strings.Split(out, "\n")
+	for i, ln := range lines {
+		fields := strings.Fields(ln)
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], "cpu") {
+			continue
+		}
+		if i+1 >= len(lines) {
+			break
+		}
+		for _, v := range strings.Fields(lines[i+1]) {
+			if f, err := strconv.ParseFloat(strings.TrimSuffix(v, "%"), 64); err == nil {
+				pct = append(pct, f)
+			}
+		}
+	}
+	return pct
+}
+
+// findColumnRow scans out for a header line containing all of wantCols and
+// returns that header split into fields along with the next non-blank line
+// (the data row), skipping a dashed separator line under the header.
+// Returns nil, nil if no such header is found.
+func findColumnRow(out string, wantCols ...string) (header, row []string) {
+	lines := strings.Split(out, "\n")
+	for i, ln := range lines {
+		fields := strings.Fields(ln)
+		if !containsAll(fields, wantCols) {
+			continue
+		}
+		for j := i + 1; j < len(lines); j++ {
+			data := strings.Fields(lines[j])
+			if len(data) == 0 || strings.Trim(strings.Join(data, ""), "-") == "" {
+				continue
+			}
+			return fields, data
+		}
+	}
+	return nil, nil
+}
+
+func containsAll(fields []string, want []string) bool {
+	for _, w := range want {
+		found := false
+		for _, f := range fields {
+			if f == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// columnValue looks up the value in row at the same position col occupies in
+// header, by name.
+func columnValue(header, row []string, col string) (string, bool) {
+	for i, h := range header {
+		if h == col && i < len(row) {
+			return row[i], true
+		}
+	}
+	return "", false
+}
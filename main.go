@@ -2,12 +2,16 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"html/template"
+	"net/http"
 	"os"
 	"os/exec"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/shirou/gopsutil/v3/cpu"
@@ -51,6 +55,7 @@ type MemInfo struct {
 	Total       string
 	Available   string
 	Used        string
+	UsedBytes   uint64
 	UsedPercent float64
 	Free        string
 	Cached      string
@@ -78,13 +83,15 @@ type DiskUsageRow struct {
 	Warn       bool
 }
 type DiskIOStat struct {
-	Name        string
-	ReadCount   uint64
-	WriteCount  uint64
-	ReadBytes   string
-	WriteBytes  string
-	ReadTimeMS  uint64
-	WriteTimeMS uint64
+	Name          string
+	ReadCount     uint64
+	WriteCount    uint64
+	ReadBytes     string
+	ReadBytesRaw  uint64
+	WriteBytes    string
+	WriteBytesRaw uint64
+	ReadTimeMS    uint64
+	WriteTimeMS   uint64
 }
 type DiskInfo struct {
 	Rows     []DiskUsageRow
@@ -93,18 +100,21 @@ type DiskInfo struct {
 }
 
 type ErrSummary struct {
-	Total   int
-	Permanent int
-	Temporary int
+	Total         int
+	Permanent     int
+	Temporary     int
 	Informational int
-	Unknown int
+	Unknown       int
 }
 
 type ErrorLog struct {
-	AllTimeSummary   ErrSummary
-	Last24hSummary   ErrSummary
-	Last24hFullBlock string // concatenated full errpt entries in last 24h
-	Note             string
+	AllTimeSummary ErrSummary
+	Last24hSummary ErrSummary
+	Last24hEntries []ErrptEntry
+	Groups         []ErrptGroup
+	Noisiest       []ResourceCount
+	LabelTrends    []LabelTrend
+	Note           string
 }
 
 type Report struct {
@@ -114,23 +124,170 @@ type Report struct {
 	Memory      MemInfo
 	Disks       DiskInfo
 	Errors      ErrorLog
+	LPAR        LPARInfo
+	Trend       Trend
+	Verdict     HealthVerdict
+	Net         NetInfo
 }
 
 func main() {
+	serve := flag.Bool("serve", false, "run in continuous sampling mode instead of writing a single HTML report")
+	interval := flag.Duration("interval", 30*time.Second, "sampling interval when -serve is set")
+	addr := flag.String("addr", ":9100", "listen address for the Prometheus /metrics endpoint when -serve is set")
+	jsonl := flag.String("jsonl", "", "file to append newline-delimited JSON samples to when -serve is set (default: stdout)")
+	history := flag.String("history", "", "directory to persist historical samples in for trend rates and sparklines in the HTML report")
+	config := flag.String("config", "", "path to a YAML thresholds config (see montek.example.yaml); defaults are used when unset")
+	jsonOut := flag.Bool("json", false, "print the health verdict as JSON to stdout in addition to the HTML report")
+	notifyState := flag.String("notify-state", "", "file to persist the last verdict severity in, so sinks only fire on transitions")
+	notifyWebhook := flag.String("notify-webhook", "", "webhook URL to notify on verdict transitions (Slack/Mattermost/Teams)")
+	notifyWebhookFormat := flag.String("notify-webhook-format", "slack", "payload format for -notify-webhook: slack, mattermost, or teams")
+	notifySMTP := flag.String("notify-smtp", "", "SMTP host:port to notify on verdict transitions")
+	notifySMTPFrom := flag.String("notify-smtp-from", "", "From address for -notify-smtp")
+	notifySMTPTo := flag.String("notify-smtp-to", "", "comma-separated To addresses for -notify-smtp")
+	notifySyslog := flag.Bool("notify-syslog", false, "notify local syslog on verdict transitions")
+	dryRun := flag.Bool("dry-run", false, "log what each configured notification sink would send instead of sending it")
+	flag.Parse()
+
+	th, err := loadThresholds(*config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *serve {
+		runServer(*interval, *addr, *jsonl, th)
+		return
+	}
+
+	rep := collectReport(th)
+	if *history != "" {
+		if err := recordAndTrend(&rep, *history); err != nil {
+			fmt.Fprintf(os.Stderr, "history tracking disabled: %v\n", err)
+		}
+	}
+
+	rep.Verdict = evaluateHealth(rep, th)
+
+	reportHTML, err := renderHTML(rep)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to render HTML: %v\n", err)
+		os.Exit(1)
+	}
+
+	sinks := buildNotifySinks(*notifyWebhook, *notifyWebhookFormat, *notifySMTP, *notifySMTPFrom, *notifySMTPTo, *notifySyslog, *dryRun, string(reportHTML))
+	runNotifications(rep, *notifyState, sinks)
+
+	if *jsonOut {
+		data, err := json.MarshalIndent(rep.Verdict, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal verdict: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+	}
+
+	if err := os.WriteFile(outFile, reportHTML, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write HTML: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✓ Health check written to %s (verdict: %s)\n", outFile, rep.Verdict.Severity)
+	os.Exit(exitCodeFor(rep.Verdict))
+}
+
+// collectReport runs a single sampling pass across all subsystems.
+func collectReport(th Thresholds) Report {
 	rep := Report{
 		GeneratedAt: time.Now(),
 		System:      getSystemInfo(),
 	}
 	rep.CPU = getCPUInfo()
 	rep.Memory = getMemoryInfo()
-	rep.Disks = getDiskInfo()
+	rep.Disks = getDiskInfo(th)
 	rep.Errors = getErrors()
+	rep.LPAR = getLPARInfo()
+	rep.Net = getNetInfo()
+	return rep
+}
 
-	if err := writeHTML(rep, outFile); err != nil {
-		fmt.Fprintf(os.Stderr, "failed to write HTML: %v\n", err)
+// runServer polls collectReport on interval, appending each sample as a JSONL
+// record and exposing the latest sample over HTTP in Prometheus exposition
+// format, until the process is killed.
+func runServer(interval time.Duration, addr, jsonlPath string, th Thresholds) {
+	var mu sync.Mutex
+	var latest Report
+
+	sample := func() {
+		rep := collectReport(th)
+		mu.Lock()
+		latest = rep
+		mu.Unlock()
+		if err := appendJSONL(rep, jsonlPath); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to append JSON sample: %v\n", err)
+		}
+	}
+	sample()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			sample()
+		}
+	}()
+
+	http.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		rep := latest
+		mu.Unlock()
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		fmt.Fprint(w, renderPrometheus(rep))
+	})
+
+	fmt.Printf("✓ serving metrics on %s/metrics (sampling every %s)\n", addr, interval)
+	if err := http.ListenAndServe(addr, nil); err != nil {
+		fmt.Fprintf(os.Stderr, "metrics server failed: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Printf("✓ Health check written to %s\n", outFile)
+}
+
+// appendJSONL writes rep as a single JSON line to path, or to stdout if path is empty.
+func appendJSONL(rep Report, path string) error {
+	data, err := json.Marshal(rep)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if path == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(data)
+	return err
+}
+
+// renderPrometheus renders rep in Prometheus text exposition format.
+func renderPrometheus(rep Report) string {
+	var b strings.Builder
+	for i, pct := range rep.CPU.UsagePerCPU {
+		fmt.Fprintf(&b, "cpu_usage_percent{cpu=\"%d\"} %.2f\n", i, pct)
+	}
+	fmt.Fprintf(&b, "mem_used_bytes %d\n", rep.Memory.UsedBytes)
+	for _, row := range rep.Disks.Rows {
+		fmt.Fprintf(&b, "disk_used_percent{mount=%q} %.2f\n", row.Mountpoint, row.UsePct)
+	}
+	for _, io := range rep.Disks.IOStats {
+		fmt.Fprintf(&b, "disk_io_read_bytes_total{device=%q} %d\n", io.Name, io.ReadBytesRaw)
+	}
+	fmt.Fprintf(&b, "errpt_entries_total{type=\"PERM\"} %d\n", rep.Errors.AllTimeSummary.Permanent)
+	fmt.Fprintf(&b, "errpt_entries_total{type=\"TEMP\"} %d\n", rep.Errors.AllTimeSummary.Temporary)
+	fmt.Fprintf(&b, "errpt_entries_total{type=\"INFO\"} %d\n", rep.Errors.AllTimeSummary.Informational)
+	fmt.Fprintf(&b, "errpt_entries_total{type=\"UNKN\"} %d\n", rep.Errors.AllTimeSummary.Unknown)
+	return b.String()
 }
 
 func getSystemInfo() SystemInfo {
@@ -193,6 +350,7 @@ func getMemoryInfo() MemInfo {
 		m.Total = formatBytes(vm.Total)
 		m.Available = formatBytes(available)
 		m.Used = formatBytes(vm.Used)
+		m.UsedBytes = vm.Used
 		m.UsedPercent = vm.UsedPercent
 		m.Free = formatBytes(vm.Free)
 		m.Cached = formatBytes(vm.Cached)
@@ -218,7 +376,7 @@ func getMemoryInfo() MemInfo {
 	return m
 }
 
-func getDiskInfo() DiskInfo {
+func getDiskInfo(th Thresholds) DiskInfo {
 	var di DiskInfo
 
 	parts, err := disk.Partitions(false)
@@ -244,6 +402,7 @@ func getDiskInfo() DiskInfo {
 			})
 			continue
 		}
+		warnAt := resolveDiskWarnThreshold(p.Mountpoint, th)
 		row := DiskUsageRow{
 			Mountpoint: p.Mountpoint,
 			FSType:     p.Fstype,
@@ -252,7 +411,7 @@ func getDiskInfo() DiskInfo {
 			Used:       formatBytes(u.Used),
 			Free:       formatBytes(u.Free),
 			UsePct:     u.UsedPercent,
-			Warn:       u.UsedPercent > 80.0,
+			Warn:       warnAt > 0 && u.UsedPercent >= warnAt,
 		}
 		if row.Warn {
 			di.Warnings++
@@ -269,13 +428,15 @@ func getDiskInfo() DiskInfo {
 		for _, name := range names {
 			c := ioc[name]
 			di.IOStats = append(di.IOStats, DiskIOStat{
-				Name:        name,
-				ReadCount:   c.ReadCount,
-				WriteCount:  c.WriteCount,
-				ReadBytes:   formatBytes(c.ReadBytes),
-				WriteBytes:  formatBytes(c.WriteBytes),
-				ReadTimeMS:  c.ReadTime,
-				WriteTimeMS: c.WriteTime,
+				Name:          name,
+				ReadCount:     c.ReadCount,
+				WriteCount:    c.WriteCount,
+				ReadBytes:     formatBytes(c.ReadBytes),
+				ReadBytesRaw:  c.ReadBytes,
+				WriteBytes:    formatBytes(c.WriteBytes),
+				WriteBytesRaw: c.WriteBytes,
+				ReadTimeMS:    c.ReadTime,
+				WriteTimeMS:   c.WriteTime,
 			})
 		}
 	}
@@ -283,7 +444,9 @@ func getDiskInfo() DiskInfo {
 	return di
 }
 
-// getErrors builds both all-time and last-24h summaries and collects all full entries in last 24h.
+// getErrors builds both all-time and last-24h summaries, parses the last 24h
+// of entries into structured ErrptEntry values, and derives the grouping,
+// noisiest-resources, and per-label trend views the HTML report renders.
 func getErrors() ErrorLog {
 	var el ErrorLog
 
@@ -293,23 +456,26 @@ func getErrors() ErrorLog {
 		el.Note = "Error invoking errpt (AIX): " + err.Error()
 		return el
 	}
-	allEntries := splitErrptEntries(string(allOut))
-	el.AllTimeSummary = summarizeEntries(allEntries)
+	allRaw := splitErrptEntries(string(allOut))
+	allEntries := make([]ErrptEntry, 0, len(allRaw))
+	for _, raw := range allRaw {
+		allEntries = append(allEntries, parseErrptEntry(raw))
+	}
+	el.AllTimeSummary = summarizeTypedEntries(allEntries)
 
-	// last 24h filter by parsing Date/Time inside entries
+	// last 24h filter by parsed timestamp
 	cut := time.Now().Add(-24 * time.Hour)
-	var last24 []string
+	var last24 []ErrptEntry
 	for _, e := range allEntries {
-		ts, ok := extractErrptTime(e)
-		if !ok {
-			continue
-		}
-		if ts.After(cut) {
+		if !e.Timestamp.IsZero() && e.Timestamp.After(cut) {
 			last24 = append(last24, e)
 		}
 	}
-	el.Last24hSummary = summarizeEntries(last24)
-	el.Last24hFullBlock = strings.Join(last24, "\n\n")
+	el.Last24hSummary = summarizeTypedEntries(last24)
+	el.Last24hEntries = last24
+	el.Groups = groupErrptEntries(last24)
+	el.Noisiest = noisiestResources(last24, 5)
+	el.LabelTrends = buildLabelTrends(last24, time.Now())
 
 	return el
 }
@@ -362,47 +528,28 @@ func extractErrptTime(entry string) (time.Time, bool) {
 	return time.Time{}, false
 }
 
-func summarizeEntries(entries []string) ErrSummary {
-	var s ErrSummary
-	for _, e := range entries {
-		s.Total++
-		t := extractType(e)
-		switch t {
-		case "PERM":
-			s.Permanent++
-		case "TEMP":
-			s.Temporary++
-		case "INFO":
-			s.Informational++
-		default:
-			s.Unknown++
-		}
-	}
-	return s
-}
-
-func extractType(entry string) string {
-	for _, ln := range strings.Split(entry, "\n") {
-		trim := strings.TrimSpace(ln)
-		if strings.HasPrefix(trim, "Type:") {
-			v := strings.TrimSpace(strings.TrimPrefix(trim, "Type:"))
-			// Common values: PERM, TEMP, INFO, UNKN
-			return strings.ToUpper(v)
-		}
-	}
-	return ""
-}
-
-func writeHTML(rep Report, path string) error {
+// renderHTML executes htmlTemplate against rep, returning the rendered
+// bytes. Shared by the on-disk report and the SMTP notifier, so a sink email
+// looks exactly like health_check.html rather than a one-off summary.
+func renderHTML(rep Report) ([]byte, error) {
 	tpl := template.Must(template.New("page").Funcs(template.FuncMap{
-		"pct": func(f float64) string { return fmt.Sprintf("%.2f%%", f) },
+		"pct":       func(f float64) string { return fmt.Sprintf("%.2f%%", f) },
+		"sparkline": renderSparkline,
+		"sparklineInts": func(values []int) template.HTML {
+			floats := make([]float64, len(values))
+			for i, v := range values {
+				floats[i] = float64(v)
+			}
+			return renderSparkline(floats)
+		},
+		"lower": strings.ToLower,
 	}).Parse(htmlTemplate))
 
 	var buf bytes.Buffer
 	if err := tpl.Execute(&buf, rep); err != nil {
-		return err
+		return nil, err
 	}
-	return os.WriteFile(path, buf.Bytes(), 0644)
+	return buf.Bytes(), nil
 }
 
 func formatBytes(b uint64) string {
@@ -439,16 +586,29 @@ html,body{background:var(--bg);color:var(--text);font-family:system-ui,-apple-sy
 .tbl{width:100%;border-collapse:collapse;font-size:14px;background:var(--tbl);border:1px solid var(--b);overflow:hidden;border-radius:8px}
 .tbl th,.tbl td{padding:8px 10px;border-bottom:1px solid var(--b);text-align:left;vertical-align:top}
 .tbl th{font-weight:600;color:#c8d0da;background:#0f1a2f}
+.tbl.sortable th{cursor:pointer;user-select:none}
+.tbl.sortable th::after{content:"";margin-left:4px;color:var(--muted)}
+.tbl.sortable th.sort-asc::after{content:"▲"}
+.tbl.sortable th.sort-desc::after{content:"▼"}
 .badge{display:inline-block;padding:2px 8px;border-radius:999px;font-size:12px}
 .badge.ok{background:rgba(18,184,134,.15);color:var(--ok);border:1px solid rgba(18,184,134,.4)}
 .badge.warn{background:rgba(245,159,0,.15);color:var(--warn);border:1px solid rgba(245,159,0,.4)}
 pre{white-space:pre-wrap;word-wrap:break-word;background:#0f1a2f;border:1px solid var(--b);padding:12px;border-radius:8px;margin:0}
 .small{color:var(--muted);font-size:12px}
 .footer{margin-top:20px;color:var(--muted);font-size:12px;text-align:center}
+.banner{padding:10px 16px;border-radius:8px;margin-bottom:16px;font-size:14px}
+.banner-ok{background:rgba(18,184,134,.12);color:var(--ok);border:1px solid rgba(18,184,134,.4)}
+.banner-warn{background:rgba(245,159,0,.12);color:var(--warn);border:1px solid rgba(245,159,0,.4)}
+.banner-crit{background:rgba(224,49,49,.12);color:var(--bad);border:1px solid rgba(224,49,49,.4)}
 </style>
 </head>
 <body>
 <div class="wrap">
+  <div class="banner banner-{{ lower (print .Verdict.Severity) }}">
+    <strong>{{ .Verdict.Severity }}</strong>
+    {{ range $i, $r := .Verdict.Reasons }}{{ if $i }}; {{ end }}{{ $r }}{{ end }}
+  </div>
+
   <div class="hdr">
     <div class="h1">AIX Power9 System Health Check</div>
     <div class="time">{{ .GeneratedAt.Format "2006-01-02 15:04:05 MST" }}</div>
@@ -503,6 +663,7 @@ pre{white-space:pre-wrap;word-wrap:break-word;background:#0f1a2f;border:1px soli
         <div>Available</div><div>{{ .Memory.Available }}</div>
         <div>Used</div><div>{{ .Memory.Used }}</div>
         <div>Used %</div><div>{{ pct .Memory.UsedPercent }}</div>
+        {{ if .Trend.HasHistory }}<div>Trend</div><div>{{ sparkline .Trend.MemUsedPctSeries }}</div>{{ end }}
         <div>Free</div><div>{{ .Memory.Free }}</div>
         <div>Cached</div><div>{{ .Memory.Cached }}</div>
         <div>Buffers</div><div>{{ .Memory.Buffers }}</div>
@@ -515,13 +676,40 @@ pre{white-space:pre-wrap;word-wrap:break-word;background:#0f1a2f;border:1px soli
         <div>Swap In/Out</div><div>{{ .Memory.SwapSin }} / {{ .Memory.SwapSout }}</div>
       </div>
     </section>
+
+    <section class="card">
+      <h2>LPAR / Power9</h2>
+      {{ if .LPAR.Note }}<div class="small" style="margin-bottom:8px;">{{ .LPAR.Note }}</div>{{ end }}
+      <div class="kv">
+        <div>Partition Name</div><div>{{ .LPAR.PartitionName }}</div>
+        <div>Type</div><div>{{ .LPAR.Type }}</div>
+        <div>Mode</div><div>{{ .LPAR.Mode }}</div>
+        <div>Online vCPUs</div><div>{{ .LPAR.OnlineVCPUs }}</div>
+        <div>Entitled Capacity</div><div>{{ printf "%.2f" .LPAR.EntitledCapacity }}</div>
+        <div>Physical CPUs Consumed</div><div>{{ printf "%.2f" .LPAR.PhysConsumed }}</div>
+        <div>%Entc</div><div>{{ pct .LPAR.PctEntc }}</div>
+        <div>SMT Threads</div><div>{{ .LPAR.SMTThreads }}</div>
+        <div>Paging Space In/Out</div><div>{{ .LPAR.PagingSpacePageIn }} / {{ .LPAR.PagingSpacePageOut }}</div>
+      </div>
+      {{ if .LPAR.PerThreadPct }}
+        <div class="small" style="margin-top:8px;">Per-thread utilization:</div>
+        <table class="tbl" style="margin-top:6px;">
+          <thead><tr><th>Thread</th><th>Usage</th></tr></thead>
+          <tbody>
+          {{ range $i, $v := .LPAR.PerThreadPct }}
+            <tr><td>cpu{{ $i }}</td><td>{{ printf "%.2f%%" $v }}</td></tr>
+          {{ end }}
+          </tbody>
+        </table>
+      {{ end }}
+    </section>
   </div>
 
   <section class="card" style="margin-top:16px;">
     <h2>Disk Usage</h2>
     <table class="tbl">
       <thead>
-        <tr><th>Mountpoint</th><th>FS Type</th><th>Device</th><th>Total</th><th>Used</th><th>Free</th><th>Use%</th><th>Status</th></tr>
+        <tr><th>Mountpoint</th><th>FS Type</th><th>Device</th><th>Total</th><th>Used</th><th>Free</th><th>Use%</th><th>Status</th><th>Trend</th></tr>
       </thead>
       <tbody>
       {{ range .Disks.Rows }}
@@ -533,16 +721,17 @@ pre{white-space:pre-wrap;word-wrap:break-word;background:#0f1a2f;border:1px soli
           <td>{{ .Used }}</td>
           <td>{{ .Free }}</td>
           <td>{{ printf "%.2f%%" .UsePct }}</td>
-          <td>{{ if .Warn }}<span class="badge warn">> 80%</span>{{ else }}<span class="badge ok">OK</span>{{ end }}</td>
+          <td>{{ if .Warn }}<span class="badge warn">WARN</span>{{ else }}<span class="badge ok">OK</span>{{ end }}</td>
+          <td>{{ sparkline (index $.Trend.DiskUsePctSeries .Mountpoint) }}</td>
         </tr>
       {{ end }}
       </tbody>
     </table>
     <div style="margin-top:8px;">
       {{ if gt .Disks.Warnings 0 }}
-        <span class="badge warn">{{ .Disks.Warnings }} filesystem(s) > 80%</span>
+        <span class="badge warn">{{ .Disks.Warnings }} filesystem(s) over threshold</span>
       {{ else }}
-        <span class="badge ok">All filesystems below 80%</span>
+        <span class="badge ok">All filesystems within threshold</span>
       {{ end }}
     </div>
 
@@ -569,6 +758,75 @@ pre{white-space:pre-wrap;word-wrap:break-word;background:#0f1a2f;border:1px soli
     {{ end }}
   </section>
 
+  {{ if .Trend.HasHistory }}
+  <section class="card" style="margin-top:16px;">
+    <h2>Trends (since last run)</h2>
+    <div class="kv">
+      <div>Samples in window</div><div>{{ .Trend.SampleCount }}</div>
+      <div>Errpt Rate</div><div>{{ printf "%.2f" .Trend.ErrptPerHour }} entries/hour</div>
+    </div>
+    {{ if .Trend.DiskIORates }}
+      <table class="tbl" style="margin-top:8px;">
+        <thead><tr><th>Device</th><th>Read B/s</th><th>Write B/s</th></tr></thead>
+        <tbody>
+        {{ range .Trend.DiskIORates }}
+          <tr><td>{{ .Name }}</td><td>{{ printf "%.0f" .ReadBytesPerSec }}</td><td>{{ printf "%.0f" .WriteBytesPerSec }}</td></tr>
+        {{ end }}
+        </tbody>
+      </table>
+    {{ end }}
+    {{ if .Trend.NewErrptLabels }}
+      <div class="small" style="margin-top:8px;">New errpt LABELs since last run:
+        {{ range .Trend.NewErrptLabels }}<span class="badge warn">{{ . }}</span> {{ end }}
+      </div>
+    {{ end }}
+    {{ if .Trend.NewlyOver80 }}
+      <div class="small" style="margin-top:8px;">Filesystems newly over threshold:
+        {{ range .Trend.NewlyOver80 }}<span class="badge warn">{{ . }}</span> {{ end }}
+      </div>
+    {{ end }}
+  </section>
+  {{ end }}
+
+  <section class="card" style="margin-top:16px;">
+    <h2>Network</h2>
+    {{ if .Net.Note }}<div class="small" style="margin-bottom:8px;">{{ .Net.Note }}</div>{{ end }}
+    <table class="tbl">
+      <thead>
+        <tr><th>Interface</th><th>Addresses</th><th>MAC</th><th>MTU</th><th>RX Bytes</th><th>TX Bytes</th><th>RX Errs</th><th>TX Errs</th><th>Link Speed</th><th>Jumbo</th><th>EtherChannel</th></tr>
+      </thead>
+      <tbody>
+      {{ range .Net.Interfaces }}
+        <tr{{ if .HasErrorsOrDrops }} style="color:var(--warn);"{{ end }}>
+          <td>{{ .Name }}</td>
+          <td>{{ range $i, $a := .Addrs }}{{ if $i }}, {{ end }}{{ $a }}{{ end }}</td>
+          <td>{{ .MACAddress }}</td>
+          <td>{{ .MTU }}</td>
+          <td>{{ .BytesRecv }}</td>
+          <td>{{ .BytesSent }}</td>
+          <td>{{ .Errin }} / {{ .ReceiveErrors }}</td>
+          <td>{{ .Errout }} / {{ .TransmitErrors }}</td>
+          <td>{{ .LinkSpeed }}</td>
+          <td>{{ if .JumboFrames }}yes{{ else }}no{{ end }}</td>
+          <td>{{ .EtherChannelRole }}</td>
+        </tr>
+      {{ end }}
+      </tbody>
+    </table>
+
+    {{ if .Net.TCPStates }}
+      <h3 style="margin-top:16px;">TCP Connection States</h3>
+      <table class="tbl">
+        <thead><tr><th>State</th><th>Count</th></tr></thead>
+        <tbody>
+        {{ range .Net.TCPStates }}
+          <tr><td>{{ .State }}</td><td>{{ .Count }}</td></tr>
+        {{ end }}
+        </tbody>
+      </table>
+    {{ end }}
+  </section>
+
   <section class="card" style="margin-top:16px;">
     <h2>Recent OS Errors (AIX errpt)</h2>
     {{ if .Errors.Note }}<div class="small" style="margin-bottom:8px;">{{ .Errors.Note }}</div>{{ end }}
@@ -595,9 +853,50 @@ pre{white-space:pre-wrap;word-wrap:break-word;background:#0f1a2f;border:1px soli
       </div>
     </div>
 
-    {{ if .Errors.Last24hFullBlock }}
-      <h3 style="margin-top:12px;">All Errors in the Last 24 Hours</h3>
-      <pre>{{ .Errors.Last24hFullBlock }}</pre>
+    {{ if .Errors.Groups }}
+      <h3 style="margin-top:12px;">Errors in the Last 24 Hours (grouped)</h3>
+      <table class="tbl sortable">
+        <thead>
+          <tr><th>Label</th><th>Resource</th><th>Type</th><th>Description</th><th data-sort="num">Count</th><th data-sort="date">First Seen</th><th data-sort="date">Last Seen</th></tr>
+        </thead>
+        <tbody>
+        {{ range .Errors.Groups }}
+          <tr>
+            <td>{{ .Label }}</td>
+            <td>{{ .ResourceName }}</td>
+            <td>{{ .Type }}</td>
+            <td>{{ .Description }}</td>
+            <td>{{ .Count }}</td>
+            <td>{{ .FirstSeen.Format "2006-01-02 15:04:05" }}</td>
+            <td>{{ .LastSeen.Format "2006-01-02 15:04:05" }}</td>
+          </tr>
+        {{ end }}
+        </tbody>
+      </table>
+
+      {{ if .Errors.Noisiest }}
+        <h3 style="margin-top:16px;">Noisiest Resources</h3>
+        <table class="tbl">
+          <thead><tr><th>Resource</th><th>Entries (24h)</th></tr></thead>
+          <tbody>
+          {{ range .Errors.Noisiest }}
+            <tr><td>{{ .ResourceName }}</td><td>{{ .Count }}</td></tr>
+          {{ end }}
+          </tbody>
+        </table>
+      {{ end }}
+
+      {{ if .Errors.LabelTrends }}
+        <h3 style="margin-top:16px;">Per-Label Occurrences (last 24h, hourly buckets)</h3>
+        <table class="tbl">
+          <thead><tr><th>Label</th><th>Trend</th></tr></thead>
+          <tbody>
+          {{ range .Errors.LabelTrends }}
+            <tr><td>{{ .Label }}</td><td>{{ sparklineInts .Buckets }}</td></tr>
+          {{ end }}
+          </tbody>
+        </table>
+      {{ end }}
     {{ else }}
       <div class="small" style="margin-top:8px;">No errors in the last 24 hours.</div>
     {{ end }}
@@ -605,5 +904,38 @@ pre{white-space:pre-wrap;word-wrap:break-word;background:#0f1a2f;border:1px soli
 
   <div class="footer">Generated on {{ .GeneratedAt.Format "2006-01-02 15:04:05 -0700" }}</div>
 </div>
+<script>
+// Minimal click-to-sort for table.sortable: click a <th> to sort its column,
+// click again to reverse. No dependencies, since this is a static report.
+document.querySelectorAll("table.sortable").forEach(function (table) {
+  var tbody = table.querySelector("tbody");
+  var headers = table.querySelectorAll("th");
+  headers.forEach(function (th, col) {
+    th.addEventListener("click", function () {
+      var asc = !th.classList.contains("sort-asc");
+      headers.forEach(function (h) { h.classList.remove("sort-asc", "sort-desc"); });
+      th.classList.add(asc ? "sort-asc" : "sort-desc");
+
+      var kind = th.getAttribute("data-sort") || "text";
+      var rows = Array.prototype.slice.call(tbody.querySelectorAll("tr"));
+      rows.sort(function (a, b) {
+        var av = a.children[col].textContent.trim();
+        var bv = b.children[col].textContent.trim();
+        if (kind === "num") {
+          return (parseFloat(av) || 0) - (parseFloat(bv) || 0);
+        }
+        if (kind === "date") {
+          return new Date(av) - new Date(bv);
+        }
+        return av.localeCompare(bv);
+      });
+      if (!asc) {
+        rows.reverse();
+      }
+      rows.forEach(function (r) { tbody.appendChild(r); });
+    });
+  });
+});
+</script>
 </body>
 </html>`
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	gnet "github.com/shirou/gopsutil/v3/net"
+)
+
+// NetInterfaceStat merges gopsutil's portable interface/IO counters with AIX-
+// specific data netstat/entstat expose and gopsutil has no concept of:
+// transmit/receive errors, negotiated link speed, jumbo-frame status, and
+// EtherChannel role.
+type NetInterfaceStat struct {
+	Name        string
+	Addrs       []string
+	MACAddress  string
+	MTU         int
+	Flags       []string
+	BytesSent   uint64
+	BytesRecv   uint64
+	PacketsSent uint64
+	PacketsRecv uint64
+	Errin       uint64
+	Errout      uint64
+	Dropin      uint64
+	Dropout     uint64
+
+	LinkSpeed        string
+	JumboFrames      bool
+	EtherChannelRole string // e.g. "primary channel"; empty if not an EtherChannel
+	TransmitErrors   uint64
+	ReceiveErrors    uint64
+}
+
+// HasErrorsOrDrops reports whether this interface has any non-zero
+// error/drop counter, from either gopsutil or the AIX-specific counters.
+func (s NetInterfaceStat) HasErrorsOrDrops() bool {
+	return s.Errin > 0 || s.Errout > 0 || s.Dropin > 0 || s.Dropout > 0 ||
+		s.TransmitErrors > 0 || s.ReceiveErrors > 0
+}
+
+// TCPStateCount is one bucket of the TCP connection-state histogram.
+type TCPStateCount struct {
+	State string
+	Count int
+}
+
+type NetInfo struct {
+	Interfaces []NetInterfaceStat
+	TCPStates  []TCPStateCount
+	Note       string
+}
+
+// getNetInfo builds the network report by combining gopsutil's portable
+// counters with AIX's netstat -v / entstat -d for data gopsutil cannot
+// supply on AIX.
+func getNetInfo() NetInfo {
+	var ni NetInfo
+	var notes []string
+
+	ioCounters, err := gnet.IOCounters(true)
+	if err != nil {
+		notes = append(notes, "net.IOCounters: "+err.Error())
+	}
+	ioByName := make(map[string]gnet.IOCountersStat, len(ioCounters))
+	for _, c := range ioCounters {
+		ioByName[c.Name] = c
+	}
+
+	ifaces, err := gnet.Interfaces()
+	if err != nil {
+		notes = append(notes, "net.Interfaces: "+err.Error())
+	}
+
+	for _, iface := range ifaces {
+		stat := NetInterfaceStat{
+			Name:       iface.Name,
+			MACAddress: iface.HardwareAddr,
+			MTU:        iface.MTU,
+			Flags:      iface.Flags,
+		}
+		for _, a := range iface.Addrs {
+			stat.Addrs = append(stat.Addrs, a.Addr)
+		}
+		if c, ok := ioByName[iface.Name]; ok {
+			stat.BytesSent = c.BytesSent
+			stat.BytesRecv = c.BytesRecv
+			stat.PacketsSent = c.PacketsSent
+			stat.PacketsRecv = c.PacketsRecv
+			stat.Errin = c.Errin
+			stat.Errout = c.Errout
+			stat.Dropin = c.Dropin
+			stat.Dropout = c.Dropout
+		}
+
+		augmentWithNetstatV(&stat)
+		augmentWithEntstatD(&stat)
+
+		ni.Interfaces = append(ni.Interfaces, stat)
+	}
+
+	conns, err := gnet.Connections("tcp")
+	if err != nil {
+		notes = append(notes, "net.Connections: "+err.Error())
+	} else {
+		ni.TCPStates = tcpStateHistogram(conns)
+	}
+
+	ni.Note = strings.Join(notes, "; ")
+	return ni
+}
+
+// tcpStateHistogram tallies TCP connections by state (ESTABLISHED,
+// TIME_WAIT, etc.), sorted by state name for a stable report.
+func tcpStateHistogram(conns []gnet.ConnectionStat) []TCPStateCount {
+	counts := make(map[string]int)
+	var order []string
+	for _, c := range conns {
+		if _, ok := counts[c.Status]; !ok {
+			order = append(order, c.Status)
+		}
+		counts[c.Status]++
+	}
+	sort.Strings(order)
+	out := make([]TCPStateCount, 0, len(order))
+	for _, s := range order {
+		out = append(out, TCPStateCount{State: s, Count: counts[s]})
+	}
+	return out
+}
+
+// augmentWithNetstatV fills in transmit/receive errors, negotiated link
+// speed, and jumbo-frame status from `netstat -v <adapter>`.
+func augmentWithNetstatV(stat *NetInterfaceStat) {
+	out, err := exec.Command("netstat", "-v", stat.Name).Output()
+	if err != nil {
+		return
+	}
+	text := string(out)
+	if v, ok := fieldAfter(text, "Transmit Errors:"); ok {
+		stat.TransmitErrors, _ = strconv.ParseUint(v, 10, 64)
+	}
+	if v, ok := fieldAfter(text, "Receive Errors:"); ok {
+		stat.ReceiveErrors, _ = strconv.ParseUint(v, 10, 64)
+	}
+	if v, ok := fieldAfter(text, "Media Speed Running:"); ok {
+		stat.LinkSpeed = v
+	}
+	if v, ok := fieldAfter(text, "Jumbo Frames:"); ok {
+		stat.JumboFrames = strings.EqualFold(v, "Enabled") || strings.EqualFold(v, "Yes")
+	}
+}
+
+// augmentWithEntstatD fills in EtherChannel primary/backup role from
+// `entstat -d <adapter>`, when the adapter is part of one.
+func augmentWithEntstatD(stat *NetInterfaceStat) {
+	out, err := exec.Command("entstat", "-d", stat.Name).Output()
+	if err != nil {
+		return
+	}
+	if v, ok := fieldAfter(string(out), "Active channel:"); ok {
+		stat.EtherChannelRole = v
+	}
+}
+
+// fieldAfter returns the value following label on the same line, ending at
+// the line break or at a run of 2+ spaces that marks the start of the next
+// column in AIX's two-column netstat/entstat layout.
+func fieldAfter(text, label string) (string, bool) {
+	idx := strings.Index(text, label)
+	if idx < 0 {
+		return "", false
+	}
+	rest := text[idx+len(label):]
+	if nl := strings.IndexByte(rest, '\n'); nl >= 0 {
+		rest = rest[:nl]
+	}
+	rest = strings.TrimLeft(rest, " \t")
+
+	end := len(rest)
+	for i := 0; i < len(rest)-1; i++ {
+		if rest[i] == ' ' && rest[i+1] == ' ' {
+			end = i
+			break
+		}
+	}
+	val := strings.TrimSpace(rest[:end])
+	if val == "" {
+		return "", false
+	}
+	return val, true
+}
@@ -0,0 +1,18 @@
+package notify
+
+import (
+	"fmt"
+	"io"
+)
+
+// DryRunNotifier logs what a real sink would have sent instead of actually
+// sending it, for use behind a -dry-run flag.
+type DryRunNotifier struct {
+	Sink string
+	Out  io.Writer
+}
+
+func (d *DryRunNotifier) Notify(t Transition) error {
+	_, err := fmt.Fprintf(d.Out, "[dry-run] %s would notify: %s (%s)\n", d.Sink, t.String(), t.reasonsJoined())
+	return err
+}
@@ -0,0 +1,42 @@
+// Package notify implements pluggable sinks that fire when montek's overall
+// health verdict changes, turning the tool from a report generator into a
+// proactive alerting agent for lights-out AIX shops.
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Transition describes a change in overall health severity between two
+// consecutive runs, including the special case of returning to "OK" after
+// having been WARN or CRIT ("resolved").
+type Transition struct {
+	From      string
+	To        string
+	Reasons   []string
+	Timestamp time.Time
+}
+
+// Resolved reports whether this transition represents a return to OK from a
+// non-OK state.
+func (t Transition) Resolved() bool {
+	return t.To == "OK" && t.From != "OK" && t.From != ""
+}
+
+func (t Transition) String() string {
+	if t.Resolved() {
+		return fmt.Sprintf("RESOLVED (was %s)", t.From)
+	}
+	return fmt.Sprintf("%s -> %s", t.From, t.To)
+}
+
+func (t Transition) reasonsJoined() string {
+	return strings.Join(t.Reasons, "; ")
+}
+
+// Notifier sends a Transition to an external system.
+type Notifier interface {
+	Notify(t Transition) error
+}
@@ -0,0 +1,39 @@
+package notify
+
+import (
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+// SMTPNotifier emails a Transition as an HTML message. Body is the full
+// rendered HTML report (the same markup montek writes to health_check.html),
+// so the email looks like the report rather than a one-off summary.
+type SMTPNotifier struct {
+	Addr string // host:port
+	From string
+	To   []string
+	Auth smtp.Auth
+	Body string
+}
+
+func (s *SMTPNotifier) Notify(t Transition) error {
+	msg := buildHTMLMessage(s.From, s.To, fmt.Sprintf("[montek] %s", t.String()), s.Body)
+	return smtp.SendMail(s.Addr, s.Auth, s.From, s.To, msg)
+}
+
+// buildHTMLMessage renders a minimal multipart/alternative message with a
+// plain-text fallback alongside the HTML part.
+func buildHTMLMessage(from string, to []string, subject, htmlBody string) []byte {
+	const boundary = "montek-boundary"
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&b, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&b, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", boundary)
+	fmt.Fprintf(&b, "--%s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n\r\n", boundary, subject)
+	fmt.Fprintf(&b, "--%s\r\nContent-Type: text/html; charset=UTF-8\r\n\r\n%s\r\n\r\n", boundary, htmlBody)
+	fmt.Fprintf(&b, "--%s--\r\n", boundary)
+	return []byte(b.String())
+}
@@ -0,0 +1,37 @@
+package notify
+
+import (
+	"fmt"
+	"log/syslog"
+)
+
+// AIXFacilityDaemon is the conventional syslog.conf facility for a
+// monitoring agent like montek; AIX maps it onto the same LOG_DAEMON code as
+// other Unixes.
+const AIXFacilityDaemon = syslog.LOG_DAEMON
+
+// SyslogNotifier writes a Transition to the local syslog at a severity
+// matching the new verdict.
+type SyslogNotifier struct {
+	writer *syslog.Writer
+}
+
+func NewSyslogNotifier(tag string, facility syslog.Priority) (*SyslogNotifier, error) {
+	w, err := syslog.New(facility|syslog.LOG_INFO, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogNotifier{writer: w}, nil
+}
+
+func (s *SyslogNotifier) Notify(t Transition) error {
+	msg := fmt.Sprintf("montek verdict %s: %s", t.String(), t.reasonsJoined())
+	switch t.To {
+	case "CRIT":
+		return s.writer.Crit(msg)
+	case "WARN":
+		return s.writer.Warning(msg)
+	default:
+		return s.writer.Info(msg)
+	}
+}
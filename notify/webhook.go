@@ -0,0 +1,56 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WebhookNotifier posts a Transition to a generic JSON incoming webhook,
+// formatted for Slack, Mattermost (Slack-compatible), or Microsoft Teams.
+type WebhookNotifier struct {
+	URL    string
+	Format string // "slack", "mattermost", or "teams"
+	Client *http.Client
+}
+
+func (w *WebhookNotifier) Notify(t Transition) error {
+	data, err := json.Marshal(w.payload(t))
+	if err != nil {
+		return err
+	}
+	resp, err := w.client().Post(w.URL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (w *WebhookNotifier) client() *http.Client {
+	if w.Client != nil {
+		return w.Client
+	}
+	return &http.Client{Timeout: 10 * time.Second}
+}
+
+func (w *WebhookNotifier) payload(t Transition) any {
+	if w.Format == "teams" {
+		return map[string]any{
+			"@type":    "MessageCard",
+			"@context": "http://schema.org/extensions",
+			"title":    fmt.Sprintf("montek: %s", t.String()),
+			"text":     strings.Join(t.Reasons, "\n\n"),
+		}
+	}
+	// Slack and Mattermost both accept the same minimal {"text": ...} payload.
+	return map[string]any{
+		"text": fmt.Sprintf("montek: %s\n%s", t.String(), t.reasonsJoined()),
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dynnian/montek/notify"
+)
+
+// notifyRateLimitWindow caps how often any single sink fires, regardless of
+// how many transitions arrive during that span.
+const notifyRateLimitWindow = 15 * time.Minute
+
+// namedSink pairs a notifier with the name its rate-limit state is keyed
+// under in verdictState.LastNotify.
+type namedSink struct {
+	Name     string
+	Notifier notify.Notifier
+}
+
+// runNotifications compares rep.Verdict against the severity last persisted
+// to statePath and fires every sink on a transition (OK->WARN, WARN->CRIT,
+// or back down to OK), skipping any sink that already fired within
+// notifyRateLimitWindow. Notifications are disabled entirely when statePath
+// is empty or no sinks are configured. The very first run with a given
+// statePath only seeds the file with the current severity: with no prior
+// state there is no real transition to report, so sinks are not fired.
+func runNotifications(rep Report, statePath string, sinks []namedSink) {
+	if statePath == "" || len(sinks) == 0 {
+		return
+	}
+
+	state, hadState, err := readVerdictState(statePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read verdict state %s: %v\n", statePath, err)
+		return
+	}
+	if !hadState {
+		state.Severity = string(rep.Verdict.Severity)
+		if err := writeVerdictState(statePath, state); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to persist verdict state: %v\n", err)
+		}
+		return
+	}
+	if state.Severity == string(rep.Verdict.Severity) {
+		return
+	}
+
+	t := notify.Transition{
+		From:      state.Severity,
+		To:        string(rep.Verdict.Severity),
+		Reasons:   rep.Verdict.Reasons,
+		Timestamp: rep.GeneratedAt,
+	}
+	if state.LastNotify == nil {
+		state.LastNotify = make(map[string]time.Time)
+	}
+	for _, sink := range sinks {
+		if last, ok := state.LastNotify[sink.Name]; ok && t.Timestamp.Sub(last) < notifyRateLimitWindow {
+			continue
+		}
+		if err := sink.Notifier.Notify(t); err != nil {
+			fmt.Fprintf(os.Stderr, "notify: %v\n", err)
+			continue
+		}
+		state.LastNotify[sink.Name] = t.Timestamp
+	}
+
+	state.Severity = string(rep.Verdict.Severity)
+	if err := writeVerdictState(statePath, state); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to persist verdict state: %v\n", err)
+	}
+}
+
+// verdictState is the on-disk record runNotifications uses both to detect
+// severity transitions and to rate-limit each sink across process
+// invocations (montek is a one-shot CLI, so this can't live in memory).
+type verdictState struct {
+	Severity   string               `json:"severity"`
+	LastNotify map[string]time.Time `json:"last_notify,omitempty"`
+}
+
+// readVerdictState returns the persisted state and whether a state file was
+// actually found, distinguishing "first run" (no file, no transition, nil
+// error) from a genuine prior severity. A file that exists but fails to read
+// or parse (e.g. a truncated write from a process killed mid-write) is
+// neither: it is reported as an error rather than treated as first-run, so
+// callers don't silently reinitialize the baseline and swallow whatever real
+// transition the corrupted file obscures.
+func readVerdictState(path string) (verdictState, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return verdictState{}, false, nil
+		}
+		return verdictState{}, false, err
+	}
+	var s verdictState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return verdictState{}, false, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return s, true, nil
+}
+
+func writeVerdictState(path string, s verdictState) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// buildNotifySinks wires up the configured notifier sinks from CLI flags.
+// Each sink is rate-limited to one notification per notifyRateLimitWindow by
+// runNotifications, keyed on the name returned here. When dryRun is set
+// every sink is replaced with one that only logs what would have been sent.
+func buildNotifySinks(webhookURL, webhookFormat, smtpAddr, smtpFrom, smtpTo string, useSyslog, dryRun bool, reportHTML string) []namedSink {
+	var sinks []namedSink
+
+	add := func(name string, n notify.Notifier) {
+		if dryRun {
+			n = &notify.DryRunNotifier{Sink: name, Out: os.Stdout}
+		}
+		sinks = append(sinks, namedSink{Name: name, Notifier: n})
+	}
+
+	if webhookURL != "" {
+		add("webhook", &notify.WebhookNotifier{URL: webhookURL, Format: webhookFormat})
+	}
+	if smtpAddr != "" && smtpFrom != "" && smtpTo != "" {
+		add("smtp", &notify.SMTPNotifier{
+			Addr: smtpAddr,
+			From: smtpFrom,
+			To:   strings.Split(smtpTo, ","),
+			Body: reportHTML,
+		})
+	}
+	if useSyslog {
+		sn, err := notify.NewSyslogNotifier("montek", notify.AIXFacilityDaemon)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "syslog notifier disabled: %v\n", err)
+		} else {
+			add("syslog", sn)
+		}
+	}
+
+	return sinks
+}
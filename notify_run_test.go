@@ -0,0 +1,125 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dynnian/montek/notify"
+)
+
+// fakeNotifier records how many times it was called, for asserting on
+// runNotifications' transition/rate-limit decisions without a real sink.
+type fakeNotifier struct {
+	calls int
+}
+
+func (f *fakeNotifier) Notify(notify.Transition) error {
+	f.calls++
+	return nil
+}
+
+func repWithSeverity(sev Severity) Report {
+	return Report{
+		GeneratedAt: time.Date(2026, 7, 26, 12, 0, 0, 0, time.UTC),
+		Verdict:     HealthVerdict{Severity: sev},
+	}
+}
+
+func TestRunNotifications_FirstRunSeedsWithoutNotifying(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	sink := &fakeNotifier{}
+
+	runNotifications(repWithSeverity(SeverityWarn), path, []namedSink{{Name: "fake", Notifier: sink}})
+
+	if sink.calls != 0 {
+		t.Errorf("sink.calls = %d, want 0 on first run", sink.calls)
+	}
+	state, hadState, err := readVerdictState(path)
+	if err != nil || !hadState {
+		t.Fatalf("readVerdictState() = %+v, %v, %v; want a seeded file", state, hadState, err)
+	}
+	if state.Severity != string(SeverityWarn) {
+		t.Errorf("seeded severity = %s, want %s", state.Severity, SeverityWarn)
+	}
+}
+
+func TestRunNotifications_FiresOnTransition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := writeVerdictState(path, verdictState{Severity: string(SeverityOK)}); err != nil {
+		t.Fatal(err)
+	}
+	sink := &fakeNotifier{}
+
+	runNotifications(repWithSeverity(SeverityCrit), path, []namedSink{{Name: "fake", Notifier: sink}})
+
+	if sink.calls != 1 {
+		t.Errorf("sink.calls = %d, want 1 on OK->CRIT transition", sink.calls)
+	}
+	state, _, _ := readVerdictState(path)
+	if state.Severity != string(SeverityCrit) {
+		t.Errorf("persisted severity = %s, want %s", state.Severity, SeverityCrit)
+	}
+}
+
+func TestRunNotifications_NoOpWhenSeverityUnchanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := writeVerdictState(path, verdictState{Severity: string(SeverityWarn)}); err != nil {
+		t.Fatal(err)
+	}
+	sink := &fakeNotifier{}
+
+	runNotifications(repWithSeverity(SeverityWarn), path, []namedSink{{Name: "fake", Notifier: sink}})
+
+	if sink.calls != 0 {
+		t.Errorf("sink.calls = %d, want 0 when severity is unchanged", sink.calls)
+	}
+}
+
+func TestRunNotifications_RateLimitsPerSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	now := time.Date(2026, 7, 26, 12, 0, 0, 0, time.UTC)
+	seeded := verdictState{
+		Severity:   string(SeverityOK),
+		LastNotify: map[string]time.Time{"fake": now.Add(-time.Minute)},
+	}
+	if err := writeVerdictState(path, seeded); err != nil {
+		t.Fatal(err)
+	}
+	sink := &fakeNotifier{}
+
+	rep := repWithSeverity(SeverityWarn)
+	rep.GeneratedAt = now
+	runNotifications(rep, path, []namedSink{{Name: "fake", Notifier: sink}})
+
+	if sink.calls != 0 {
+		t.Errorf("sink.calls = %d, want 0: last fired a minute ago, inside the %s window", sink.calls, notifyRateLimitWindow)
+	}
+	state, _, _ := readVerdictState(path)
+	if state.Severity != string(SeverityWarn) {
+		t.Errorf("persisted severity = %s, want %s even though the sink was rate-limited", state.Severity, SeverityWarn)
+	}
+}
+
+func TestRunNotifications_CorruptedStateIsNotTreatedAsFirstRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	const truncated = `{"severity":"CR`
+	if err := os.WriteFile(path, []byte(truncated), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sink := &fakeNotifier{}
+
+	runNotifications(repWithSeverity(SeverityOK), path, []namedSink{{Name: "fake", Notifier: sink}})
+
+	if sink.calls != 0 {
+		t.Errorf("sink.calls = %d, want 0: a corrupted state file must not fire sinks", sink.calls)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != truncated {
+		t.Errorf("state file = %q, want it left untouched as %q (not silently reseeded)", data, truncated)
+	}
+}